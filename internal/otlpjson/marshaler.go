@@ -0,0 +1,511 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpjson renders pdata structures as the canonical OTLP JSON
+// encoding, i.e. the protobuf->JSON mapping defined by the OTLP spec
+// (base64 trace/span IDs, stringValue/intValue/... wrappers for AnyValue,
+// decimal strings for 64-bit integers). It is a peer of otlptext: where
+// otlptext produces a free-form dump for humans, otlpjson produces output
+// that downstream tooling and the OTLP JSON unmarshalers can consume.
+//
+// Wiring a "format: json" config option into the file exporter and logging
+// exporter so they can select this marshaler is deferred: neither exporter
+// exists in this tree to wire it into. That wiring is still outstanding and
+// should land alongside (or immediately after) those exporters.
+package otlpjson // import "go.opentelemetry.io/collector/internal/otlpjson"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// MarshalLogs renders ld as canonical OTLP JSON.
+func MarshalLogs(ld pdata.Logs) ([]byte, error) {
+	rls := ld.ResourceLogs()
+	out := make([]interface{}, 0, rls.Len())
+	for i := 0; i < rls.Len(); i++ {
+		out = append(out, resourceLogsToJSON(rls.At(i)))
+	}
+	return json.Marshal(map[string]interface{}{"resourceLogs": out})
+}
+
+// MarshalTraces renders td as canonical OTLP JSON.
+func MarshalTraces(td pdata.Traces) ([]byte, error) {
+	rss := td.ResourceSpans()
+	out := make([]interface{}, 0, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		out = append(out, resourceSpansToJSON(rss.At(i)))
+	}
+	return json.Marshal(map[string]interface{}{"resourceSpans": out})
+}
+
+// MarshalMetrics renders md as canonical OTLP JSON.
+func MarshalMetrics(md pdata.Metrics) ([]byte, error) {
+	rms := md.ResourceMetrics()
+	out := make([]interface{}, 0, rms.Len())
+	for i := 0; i < rms.Len(); i++ {
+		out = append(out, resourceMetricsToJSON(rms.At(i)))
+	}
+	return json.Marshal(map[string]interface{}{"resourceMetrics": out})
+}
+
+func resourceLogsToJSON(rl pdata.ResourceLogs) map[string]interface{} {
+	sls := rl.ScopeLogs()
+	scopeLogs := make([]interface{}, 0, sls.Len())
+	for i := 0; i < sls.Len(); i++ {
+		scopeLogs = append(scopeLogs, scopeLogsToJSON(sls.At(i)))
+	}
+	m := map[string]interface{}{
+		"resource":  resourceToJSON(rl.Resource()),
+		"scopeLogs": scopeLogs,
+	}
+	if schemaURL := rl.SchemaUrl(); schemaURL != "" {
+		m["schemaUrl"] = schemaURL
+	}
+	return m
+}
+
+func scopeLogsToJSON(sl pdata.ScopeLogs) map[string]interface{} {
+	lrs := sl.LogRecords()
+	logRecords := make([]interface{}, 0, lrs.Len())
+	for i := 0; i < lrs.Len(); i++ {
+		logRecords = append(logRecords, logRecordToJSON(lrs.At(i)))
+	}
+	m := map[string]interface{}{
+		"scope":      scopeToJSON(sl.Scope()),
+		"logRecords": logRecords,
+	}
+	if schemaURL := sl.SchemaUrl(); schemaURL != "" {
+		m["schemaUrl"] = schemaURL
+	}
+	return m
+}
+
+func logRecordToJSON(lr pdata.LogRecord) map[string]interface{} {
+	m := map[string]interface{}{
+		"timeUnixNano":           uint64ToJSON(uint64(lr.Timestamp())),
+		"observedTimeUnixNano":   uint64ToJSON(uint64(lr.ObservedTimestamp())),
+		"severityNumber":         severityNumberToJSON(lr.SeverityNumber()),
+		"severityText":           lr.SeverityText(),
+		"body":                   anyValueToJSON(lr.Body()),
+		"attributes":             attributesToJSON(lr.Attributes()),
+		"droppedAttributesCount": lr.DroppedAttributesCount(),
+		"flags":                  lr.Flags(),
+	}
+	if !lr.TraceID().IsEmpty() {
+		m["traceId"] = traceIDToJSON(lr.TraceID())
+	}
+	if !lr.SpanID().IsEmpty() {
+		m["spanId"] = spanIDToJSON(lr.SpanID())
+	}
+	return m
+}
+
+func resourceSpansToJSON(rs pdata.ResourceSpans) map[string]interface{} {
+	sss := rs.ScopeSpans()
+	scopeSpans := make([]interface{}, 0, sss.Len())
+	for i := 0; i < sss.Len(); i++ {
+		scopeSpans = append(scopeSpans, scopeSpansToJSON(sss.At(i)))
+	}
+	m := map[string]interface{}{
+		"resource":   resourceToJSON(rs.Resource()),
+		"scopeSpans": scopeSpans,
+	}
+	if schemaURL := rs.SchemaUrl(); schemaURL != "" {
+		m["schemaUrl"] = schemaURL
+	}
+	return m
+}
+
+func scopeSpansToJSON(ss pdata.ScopeSpans) map[string]interface{} {
+	spans := ss.Spans()
+	out := make([]interface{}, 0, spans.Len())
+	for i := 0; i < spans.Len(); i++ {
+		out = append(out, spanToJSON(spans.At(i)))
+	}
+	m := map[string]interface{}{
+		"scope": scopeToJSON(ss.Scope()),
+		"spans": out,
+	}
+	if schemaURL := ss.SchemaUrl(); schemaURL != "" {
+		m["schemaUrl"] = schemaURL
+	}
+	return m
+}
+
+func spanToJSON(sp pdata.Span) map[string]interface{} {
+	m := map[string]interface{}{
+		"traceId":                traceIDToJSON(sp.TraceID()),
+		"spanId":                 spanIDToJSON(sp.SpanID()),
+		"traceState":             sp.TraceState().AsRaw(),
+		"name":                   sp.Name(),
+		"kind":                   spanKindToJSON(sp.Kind()),
+		"startTimeUnixNano":      uint64ToJSON(uint64(sp.StartTimestamp())),
+		"endTimeUnixNano":        uint64ToJSON(uint64(sp.EndTimestamp())),
+		"attributes":             attributesToJSON(sp.Attributes()),
+		"droppedAttributesCount": sp.DroppedAttributesCount(),
+		"events":                 spanEventsToJSON(sp.Events()),
+		"droppedEventsCount":     sp.DroppedEventsCount(),
+		"links":                  spanLinksToJSON(sp.Links()),
+		"droppedLinksCount":      sp.DroppedLinksCount(),
+		"status": map[string]interface{}{
+			"message": sp.Status().Message(),
+			"code":    statusCodeToJSON(sp.Status().Code()),
+		},
+	}
+	if !sp.ParentSpanID().IsEmpty() {
+		m["parentSpanId"] = spanIDToJSON(sp.ParentSpanID())
+	}
+	return m
+}
+
+func spanEventsToJSON(se pdata.SpanEventSlice) []interface{} {
+	out := make([]interface{}, 0, se.Len())
+	for i := 0; i < se.Len(); i++ {
+		e := se.At(i)
+		out = append(out, map[string]interface{}{
+			"timeUnixNano":           uint64ToJSON(uint64(e.Timestamp())),
+			"name":                   e.Name(),
+			"attributes":             attributesToJSON(e.Attributes()),
+			"droppedAttributesCount": e.DroppedAttributesCount(),
+		})
+	}
+	return out
+}
+
+func spanLinksToJSON(sl pdata.SpanLinkSlice) []interface{} {
+	out := make([]interface{}, 0, sl.Len())
+	for i := 0; i < sl.Len(); i++ {
+		l := sl.At(i)
+		out = append(out, map[string]interface{}{
+			"traceId":                traceIDToJSON(l.TraceID()),
+			"spanId":                 spanIDToJSON(l.SpanID()),
+			"traceState":             l.TraceState().AsRaw(),
+			"attributes":             attributesToJSON(l.Attributes()),
+			"droppedAttributesCount": l.DroppedAttributesCount(),
+		})
+	}
+	return out
+}
+
+func resourceMetricsToJSON(rm pdata.ResourceMetrics) map[string]interface{} {
+	sms := rm.ScopeMetrics()
+	scopeMetrics := make([]interface{}, 0, sms.Len())
+	for i := 0; i < sms.Len(); i++ {
+		scopeMetrics = append(scopeMetrics, scopeMetricsToJSON(sms.At(i)))
+	}
+	m := map[string]interface{}{
+		"resource":     resourceToJSON(rm.Resource()),
+		"scopeMetrics": scopeMetrics,
+	}
+	if schemaURL := rm.SchemaUrl(); schemaURL != "" {
+		m["schemaUrl"] = schemaURL
+	}
+	return m
+}
+
+func scopeMetricsToJSON(sm pdata.ScopeMetrics) map[string]interface{} {
+	ms := sm.Metrics()
+	out := make([]interface{}, 0, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		out = append(out, metricToJSON(ms.At(i)))
+	}
+	m := map[string]interface{}{
+		"scope":   scopeToJSON(sm.Scope()),
+		"metrics": out,
+	}
+	if schemaURL := sm.SchemaUrl(); schemaURL != "" {
+		m["schemaUrl"] = schemaURL
+	}
+	return m
+}
+
+func metricToJSON(m pdata.Metric) map[string]interface{} {
+	out := map[string]interface{}{
+		"name":        m.Name(),
+		"description": m.Description(),
+		"unit":        m.Unit(),
+	}
+	switch m.DataType() {
+	case pdata.MetricDataTypeGauge:
+		out["gauge"] = map[string]interface{}{
+			"dataPoints": numberDataPointsToJSON(m.Gauge().DataPoints()),
+		}
+	case pdata.MetricDataTypeSum:
+		sum := m.Sum()
+		out["sum"] = map[string]interface{}{
+			"dataPoints":             numberDataPointsToJSON(sum.DataPoints()),
+			"aggregationTemporality": aggregationTemporalityToJSON(sum.AggregationTemporality()),
+			"isMonotonic":            sum.IsMonotonic(),
+		}
+	case pdata.MetricDataTypeHistogram:
+		hist := m.Histogram()
+		out["histogram"] = map[string]interface{}{
+			"dataPoints":             histogramDataPointsToJSON(hist.DataPoints()),
+			"aggregationTemporality": aggregationTemporalityToJSON(hist.AggregationTemporality()),
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		eh := m.ExponentialHistogram()
+		out["exponentialHistogram"] = map[string]interface{}{
+			"dataPoints":             expoHistogramDataPointsToJSON(eh.DataPoints()),
+			"aggregationTemporality": aggregationTemporalityToJSON(eh.AggregationTemporality()),
+		}
+	case pdata.MetricDataTypeSummary:
+		out["summary"] = map[string]interface{}{
+			"dataPoints": summaryDataPointsToJSON(m.Summary().DataPoints()),
+		}
+	}
+	return out
+}
+
+func numberDataPointsToJSON(ps pdata.NumberDataPointSlice) []interface{} {
+	out := make([]interface{}, 0, ps.Len())
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		dp := map[string]interface{}{
+			"attributes":        attributesToJSON(p.Attributes()),
+			"startTimeUnixNano": uint64ToJSON(uint64(p.StartTimestamp())),
+			"timeUnixNano":      uint64ToJSON(uint64(p.Timestamp())),
+			"flags":             p.Flags(),
+		}
+		switch p.ValueType() {
+		case pdata.MetricValueTypeInt:
+			dp["asInt"] = int64ToJSON(p.IntVal())
+		case pdata.MetricValueTypeDouble:
+			dp["asDouble"] = p.DoubleVal()
+		}
+		out = append(out, dp)
+	}
+	return out
+}
+
+func histogramDataPointsToJSON(ps pdata.HistogramDataPointSlice) []interface{} {
+	out := make([]interface{}, 0, ps.Len())
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		out = append(out, map[string]interface{}{
+			"attributes":        attributesToJSON(p.Attributes()),
+			"startTimeUnixNano": uint64ToJSON(uint64(p.StartTimestamp())),
+			"timeUnixNano":      uint64ToJSON(uint64(p.Timestamp())),
+			"count":             uint64ToJSON(p.Count()),
+			"sum":               p.Sum(),
+			"bucketCounts":      uint64SliceToJSON(p.BucketCounts()),
+			"explicitBounds":    p.ExplicitBounds(),
+			"flags":             p.Flags(),
+		})
+	}
+	return out
+}
+
+func expoHistogramDataPointsToJSON(ps pdata.ExponentialHistogramDataPointSlice) []interface{} {
+	out := make([]interface{}, 0, ps.Len())
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		out = append(out, map[string]interface{}{
+			"attributes":        attributesToJSON(p.Attributes()),
+			"startTimeUnixNano": uint64ToJSON(uint64(p.StartTimestamp())),
+			"timeUnixNano":      uint64ToJSON(uint64(p.Timestamp())),
+			"count":             uint64ToJSON(p.Count()),
+			"sum":               p.Sum(),
+			"scale":             p.Scale(),
+			"zeroCount":         uint64ToJSON(p.ZeroCount()),
+			"positive":          expoHistogramBucketsToJSON(p.Positive()),
+			"negative":          expoHistogramBucketsToJSON(p.Negative()),
+			"flags":             p.Flags(),
+		})
+	}
+	return out
+}
+
+func expoHistogramBucketsToJSON(b pdata.Buckets) map[string]interface{} {
+	return map[string]interface{}{
+		"offset":       b.Offset(),
+		"bucketCounts": uint64SliceToJSON(b.BucketCounts()),
+	}
+}
+
+func summaryDataPointsToJSON(ps pdata.SummaryDataPointSlice) []interface{} {
+	out := make([]interface{}, 0, ps.Len())
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		qs := p.QuantileValues()
+		quantiles := make([]interface{}, 0, qs.Len())
+		for j := 0; j < qs.Len(); j++ {
+			q := qs.At(j)
+			quantiles = append(quantiles, map[string]interface{}{
+				"quantile": q.Quantile(),
+				"value":    q.Value(),
+			})
+		}
+		out = append(out, map[string]interface{}{
+			"attributes":        attributesToJSON(p.Attributes()),
+			"startTimeUnixNano": uint64ToJSON(uint64(p.StartTimestamp())),
+			"timeUnixNano":      uint64ToJSON(uint64(p.Timestamp())),
+			"count":             uint64ToJSON(p.Count()),
+			"sum":               p.Sum(),
+			"quantileValues":    quantiles,
+			"flags":             p.Flags(),
+		})
+	}
+	return out
+}
+
+func resourceToJSON(r pdata.Resource) map[string]interface{} {
+	return map[string]interface{}{
+		"attributes":             attributesToJSON(r.Attributes()),
+		"droppedAttributesCount": r.DroppedAttributesCount(),
+	}
+}
+
+func scopeToJSON(il pdata.InstrumentationScope) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    il.Name(),
+		"version": il.Version(),
+	}
+}
+
+func attributesToJSON(m pdata.Map) []interface{} {
+	out := make([]interface{}, 0, m.Len())
+	m.Range(func(k string, v pdata.Value) bool {
+		out = append(out, map[string]interface{}{
+			"key":   k,
+			"value": anyValueToJSON(v),
+		})
+		return true
+	})
+	return out
+}
+
+// anyValueToJSON renders a pdata.Value using the OTLP AnyValue oneof
+// wrappers (stringValue, boolValue, intValue, ...), matching the protobuf
+// JSON mapping rather than collapsing to a bare JSON scalar.
+func anyValueToJSON(v pdata.Value) map[string]interface{} {
+	switch v.Type() {
+	case pdata.ValueTypeString:
+		return map[string]interface{}{"stringValue": v.StringVal()}
+	case pdata.ValueTypeBool:
+		return map[string]interface{}{"boolValue": v.BoolVal()}
+	case pdata.ValueTypeInt:
+		return map[string]interface{}{"intValue": int64ToJSON(v.IntVal())}
+	case pdata.ValueTypeDouble:
+		return map[string]interface{}{"doubleValue": v.DoubleVal()}
+	case pdata.ValueTypeSlice:
+		s := v.SliceVal()
+		values := make([]interface{}, 0, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			values = append(values, anyValueToJSON(s.At(i)))
+		}
+		return map[string]interface{}{"arrayValue": map[string]interface{}{"values": values}}
+	case pdata.ValueTypeMap:
+		return map[string]interface{}{"kvlistValue": map[string]interface{}{"values": attributesToJSON(v.MapVal())}}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// int64ToJSON and uint64ToJSON render 64-bit integers as decimal strings,
+// per the OTLP/protobuf JSON mapping for int64/fixed64/uint64 fields
+// (encoding.TextMarshaler-style numbers lose precision in JS/JSON number
+// parsers above 2^53).
+func int64ToJSON(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func uint64ToJSON(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func uint64SliceToJSON(vs []uint64) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = uint64ToJSON(v)
+	}
+	return out
+}
+
+// severityNumberNames, spanKindNames, statusCodeNames and the
+// aggregationTemporalityToJSON switch below map the numeric OTLP enum
+// values to the canonical JSON name strings used by the protobuf JSON
+// mapping (e.g. "SPAN_KIND_SERVER"), rather than emitting the raw integer.
+var severityNumberNames = [...]string{
+	"SEVERITY_NUMBER_UNSPECIFIED",
+	"SEVERITY_NUMBER_TRACE", "SEVERITY_NUMBER_TRACE2", "SEVERITY_NUMBER_TRACE3", "SEVERITY_NUMBER_TRACE4",
+	"SEVERITY_NUMBER_DEBUG", "SEVERITY_NUMBER_DEBUG2", "SEVERITY_NUMBER_DEBUG3", "SEVERITY_NUMBER_DEBUG4",
+	"SEVERITY_NUMBER_INFO", "SEVERITY_NUMBER_INFO2", "SEVERITY_NUMBER_INFO3", "SEVERITY_NUMBER_INFO4",
+	"SEVERITY_NUMBER_WARN", "SEVERITY_NUMBER_WARN2", "SEVERITY_NUMBER_WARN3", "SEVERITY_NUMBER_WARN4",
+	"SEVERITY_NUMBER_ERROR", "SEVERITY_NUMBER_ERROR2", "SEVERITY_NUMBER_ERROR3", "SEVERITY_NUMBER_ERROR4",
+	"SEVERITY_NUMBER_FATAL", "SEVERITY_NUMBER_FATAL2", "SEVERITY_NUMBER_FATAL3", "SEVERITY_NUMBER_FATAL4",
+}
+
+func severityNumberToJSON(sn pdata.SeverityNumber) string {
+	if n := int32(sn); n >= 0 && int(n) < len(severityNumberNames) {
+		return severityNumberNames[n]
+	}
+	return "SEVERITY_NUMBER_UNSPECIFIED"
+}
+
+var spanKindNames = [...]string{
+	"SPAN_KIND_UNSPECIFIED",
+	"SPAN_KIND_INTERNAL",
+	"SPAN_KIND_SERVER",
+	"SPAN_KIND_CLIENT",
+	"SPAN_KIND_PRODUCER",
+	"SPAN_KIND_CONSUMER",
+}
+
+func spanKindToJSON(k pdata.SpanKind) string {
+	if n := int32(k); n >= 0 && int(n) < len(spanKindNames) {
+		return spanKindNames[n]
+	}
+	return "SPAN_KIND_UNSPECIFIED"
+}
+
+var statusCodeNames = [...]string{
+	"STATUS_CODE_UNSET",
+	"STATUS_CODE_OK",
+	"STATUS_CODE_ERROR",
+}
+
+func statusCodeToJSON(c pdata.StatusCode) string {
+	if n := int32(c); n >= 0 && int(n) < len(statusCodeNames) {
+		return statusCodeNames[n]
+	}
+	return "STATUS_CODE_UNSET"
+}
+
+var aggregationTemporalityNames = [...]string{
+	"AGGREGATION_TEMPORALITY_UNSPECIFIED",
+	"AGGREGATION_TEMPORALITY_DELTA",
+	"AGGREGATION_TEMPORALITY_CUMULATIVE",
+}
+
+func aggregationTemporalityToJSON(t pdata.MetricAggregationTemporality) string {
+	if n := int32(t); n >= 0 && int(n) < len(aggregationTemporalityNames) {
+		return aggregationTemporalityNames[n]
+	}
+	return "AGGREGATION_TEMPORALITY_UNSPECIFIED"
+}
+
+func traceIDToJSON(id pdata.TraceID) string {
+	b := id.Bytes()
+	return base64.StdEncoding.EncodeToString(b[:])
+}
+
+func spanIDToJSON(id pdata.SpanID) string {
+	b := id.Bytes()
+	return base64.StdEncoding.EncodeToString(b[:])
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestMarshalLogsRoundTrip(t *testing.T) {
+	ld := testdata.GenerateLogsManyLogRecordsSameResource(5)
+
+	b, err := MarshalLogs(ld)
+	require.NoError(t, err)
+
+	got, err := otlp.NewJSONLogsUnmarshaler().UnmarshalLogs(b)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+}
+
+func TestMarshalTracesRoundTrip(t *testing.T) {
+	td := testdata.GenerateTracesManySpansSameResource(5)
+
+	b, err := MarshalTraces(td)
+	require.NoError(t, err)
+
+	got, err := otlp.NewJSONTracesUnmarshaler().UnmarshalTraces(b)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
+
+func TestMarshalMetricsRoundTrip(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(5)
+
+	b, err := MarshalMetrics(md)
+	require.NoError(t, err)
+
+	got, err := otlp.NewJSONMetricsUnmarshaler().UnmarshalMetrics(b)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
+
+// TestMarshalTracesRoundTrip_LinksAndEvents covers spanLinksToJSON and
+// spanEventsToJSON, which the generated testdata fixtures above don't
+// exercise.
+func TestMarshalTracesRoundTrip_LinksAndEvents(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().UpsertString("service.name", "link-event-test")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("test-scope")
+
+	sp := ss.Spans().AppendEmpty()
+	sp.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	sp.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	sp.SetName("span-with-links-and-events")
+	sp.SetKind(pdata.SpanKindServer)
+	sp.Status().SetCode(pdata.StatusCodeError)
+	sp.Status().SetMessage("boom")
+
+	ev := sp.Events().AppendEmpty()
+	ev.SetName("event-1")
+	ev.Attributes().UpsertString("event.attr", "value")
+
+	link := sp.Links().AppendEmpty()
+	link.SetTraceID(pdata.NewTraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+	link.SetSpanID(pdata.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	link.Attributes().UpsertString("link.attr", "value")
+
+	b, err := MarshalTraces(td)
+	require.NoError(t, err)
+
+	got, err := otlp.NewJSONTracesUnmarshaler().UnmarshalTraces(b)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
+
+// TestMarshalMetricsRoundTrip_ExponentialHistogram covers
+// expoHistogramDataPointsToJSON, which the generated testdata fixtures
+// above don't exercise.
+func TestMarshalMetricsRoundTrip_ExponentialHistogram(t *testing.T) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().UpsertString("service.name", "expo-histogram-test")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("test-scope")
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("expo.histogram")
+	m.SetDataType(pdata.MetricDataTypeExponentialHistogram)
+	eh := m.ExponentialHistogram()
+	eh.SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+
+	dp := eh.DataPoints().AppendEmpty()
+	dp.SetCount(10)
+	dp.SetSum(42.5)
+	dp.SetScale(2)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(3)
+	dp.Positive().SetBucketCounts([]uint64{1, 2, 3})
+	dp.Negative().SetOffset(-1)
+	dp.Negative().SetBucketCounts([]uint64{4, 5})
+
+	b, err := MarshalMetrics(md)
+	require.NoError(t, err)
+
+	got, err := otlp.NewJSONMetricsUnmarshaler().UnmarshalMetrics(b)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
@@ -17,7 +17,6 @@ package otlptext // import "go.opentelemetry.io/collector/internal/otlptext"
 import (
 	"bytes"
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 
@@ -145,15 +144,6 @@ func (b *dataBuffer) logExponentialHistogramDataPoints(ps pdata.ExponentialHisto
 		b.logEntry("Sum: %f", p.Sum())
 
 		scale := int(p.Scale())
-		factor := math.Ldexp(math.Ln2, -scale)
-		// Note: the equation used here, which is
-		//   math.Exp(index * factor)
-		// reports +Inf as the _lower_ boundary of the bucket nearest
-		// infinity, which is incorrect and can be addressed in various
-		// ways.  The OTel-Go implementation of this histogram pending
-		// in https://github.com/open-telemetry/opentelemetry-go/pull/2393
-		// uses a lookup table for the last finite boundary, which can be
-		// easily computed using `math/big` (for scales up to 20).
 
 		negB := p.Negative().BucketCounts()
 		posB := p.Positive().BucketCounts()
@@ -162,8 +152,8 @@ func (b *dataBuffer) logExponentialHistogramDataPoints(ps pdata.ExponentialHisto
 			pos := len(negB) - i - 1
 			index := p.Negative().Offset() + int32(pos)
 			count := p.Negative().BucketCounts()[pos]
-			lower := math.Exp(float64(index) * factor)
-			upper := math.Exp(float64(index+1) * factor)
+			lower := LowerBoundary(scale, index)
+			upper := LowerBoundary(scale, index+1)
 			b.logEntry("Bucket (%f, %f], Count: %d", -upper, -lower, count)
 		}
 
@@ -174,8 +164,8 @@ func (b *dataBuffer) logExponentialHistogramDataPoints(ps pdata.ExponentialHisto
 		for pos := 0; pos < len(posB); pos++ {
 			index := p.Positive().Offset() + int32(pos)
 			count := p.Positive().BucketCounts()[pos]
-			lower := math.Exp(float64(index) * factor)
-			upper := math.Exp(float64(index+1) * factor)
+			lower := LowerBoundary(scale, index)
+			upper := LowerBoundary(scale, index+1)
 			b.logEntry("Bucket [%f, %f), Count: %d", lower, upper, count)
 		}
 	}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptext // import "go.opentelemetry.io/collector/internal/otlptext"
+
+import (
+	"math"
+	"math/big"
+)
+
+// maxScale is the largest exponential histogram scale this package
+// precomputes a base for. The OTel exponential histogram data model does not
+// use scales beyond this in practice.
+const maxScale = 20
+
+// expoHistogramBasePrecision is the bit precision used for the big.Float
+// arithmetic below, comfortably more than the 53 bits of a float64 mantissa
+// so that rounding to float64 in LowerBoundary is correctly rounded.
+const expoHistogramBasePrecision = 128
+
+// expoHistogramBases[scale] holds base = 2^(2^-scale) for the given scale,
+// computed once at init time as repeated square roots of 2 via math/big.
+// big.Float.Sqrt is correctly rounded, not exact, so for scale > 0 these
+// bases are irrational numbers rounded to expoHistogramBasePrecision bits —
+// enough bits of margin to round base^index correctly to float64 in
+// LowerBoundary, even near the edges of the representable float64 range,
+// where math.Exp(index * math.Ldexp(math.Ln2, -scale)) loses precision and
+// can incorrectly report +Inf as a bucket's lower boundary.
+var expoHistogramBases [maxScale + 1]*big.Float
+
+func init() {
+	base := new(big.Float).SetPrec(expoHistogramBasePrecision).SetInt64(2)
+	expoHistogramBases[0] = new(big.Float).SetPrec(expoHistogramBasePrecision).Copy(base)
+	for scale := 1; scale <= maxScale; scale++ {
+		base = new(big.Float).SetPrec(expoHistogramBasePrecision).Sqrt(base)
+		expoHistogramBases[scale] = new(big.Float).SetPrec(expoHistogramBasePrecision).Copy(base)
+	}
+}
+
+// LowerBoundary returns the lower boundary of the exponential histogram
+// bucket at the given scale and index, i.e. base^index where
+// base = 2^(2^-scale). The result is clamped to the largest finite float64
+// for indices at or beyond the point where base^index would overflow to
+// +Inf, and to 0 for indices far enough below zero that base^index
+// underflows. scale is expected to be in [0, maxScale]; out-of-range scales
+// fall back to scale 0 rather than panicking, since this is rendering code
+// and must not crash on a malformed data point.
+func LowerBoundary(scale int, index int32) float64 {
+	if scale < 0 || scale > maxScale {
+		scale = 0
+	}
+	base := expoHistogramBases[scale]
+
+	exp := int64(index)
+	neg := exp < 0
+	if neg {
+		exp = -exp
+	}
+
+	result := new(big.Float).SetPrec(expoHistogramBasePrecision).SetInt64(1)
+	b := new(big.Float).SetPrec(expoHistogramBasePrecision).Copy(base)
+	for e := exp; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result.Mul(result, b)
+		}
+		b.Mul(b, b)
+	}
+	if neg {
+		result.Quo(new(big.Float).SetPrec(expoHistogramBasePrecision).SetInt64(1), result)
+	}
+
+	f, _ := result.Float64()
+	switch {
+	case math.IsInf(f, 1):
+		return math.MaxFloat64
+	case f == 0:
+		return 0
+	default:
+		return f
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptext
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowerBoundary(t *testing.T) {
+	// For scale 0, base is exactly 2, so boundaries are exact powers of two.
+	assert.Equal(t, 1.0, LowerBoundary(0, 0))
+	assert.Equal(t, 2.0, LowerBoundary(0, 1))
+	assert.Equal(t, 0.5, LowerBoundary(0, -1))
+	assert.Equal(t, 8.0, LowerBoundary(0, 3))
+
+	for scale := 0; scale <= 5; scale++ {
+		base := math.Pow(2, math.Ldexp(1, -scale))
+		for _, index := range []int32{-10, -1, 0, 1, 10} {
+			got := LowerBoundary(scale, index)
+			want := math.Pow(base, float64(index))
+			assert.InEpsilon(t, want, got, 1e-9, "scale %d index %d", scale, index)
+		}
+	}
+}
+
+func TestLowerBoundaryLastFiniteBucket(t *testing.T) {
+	for scale := 0; scale <= maxScale; scale++ {
+		// The exponent at which base^index overflows float64 grows with
+		// scale; walk up from index 1 by doubling until the boundary is
+		// clamped to MaxFloat64 (or the loop bound is reached) and confirm
+		// the last boundary seen before that is finite (not +Inf).
+		var lastFinite float64
+		for index := int32(1); index < math.MaxInt32/2; index *= 2 {
+			v := LowerBoundary(scale, index)
+			if v == math.MaxFloat64 {
+				break
+			}
+			lastFinite = v
+		}
+		assert.False(t, math.IsInf(lastFinite, 0), "scale %d produced an infinite lower boundary", scale)
+	}
+}
+
+func TestLowerBoundaryClampsAtExtremes(t *testing.T) {
+	assert.Equal(t, math.MaxFloat64, LowerBoundary(0, math.MaxInt32))
+	assert.Equal(t, 0.0, LowerBoundary(0, math.MinInt32))
+}
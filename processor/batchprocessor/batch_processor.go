@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// batchProcessor accepts logs, traces and metrics, places them into
+// batches, and sends the batches downstream.
+//
+// A batch is cut and sent out as soon as any of the following is true:
+//   - the batch's record count reaches cfg.SendBatchSize (a batch larger than
+//     cfg.SendBatchMaxSize is split before it is sent)
+//   - the batch's estimated serialized OTLP protobuf size reaches cfg.SendBatchMaxBytes
+//   - cfg.Timeout has elapsed since the previous batch was sent
+//
+// The record-count and byte-size limits are independent: whichever is hit
+// first cuts the batch, and the metrics recorded for the cut distinguish
+// which trigger fired.
+type batchProcessor struct {
+	logger *zap.Logger
+
+	timeout           time.Duration
+	sendBatchSize     int
+	sendBatchMaxSize  int
+	sendBatchMaxBytes int
+
+	newItem    chan interface{}
+	batch      batch
+	shutdownC  chan struct{}
+	goroutines sync.WaitGroup
+}
+
+// batch is an interface for the requests that the batchProcessor accepts
+// and batches together, one implementation per signal.
+type batch interface {
+	// export the current batch to the next consumer.
+	export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int) error
+
+	// itemCount returns the number of items (spans, log records, metric data points) in the current batch.
+	itemCount() int
+
+	// size returns the estimated serialized OTLP protobuf size in bytes of the
+	// current batch, as computed by the functions in sizer.go.
+	size() int
+
+	// add adds a request (pdata.Traces, pdata.Logs, pdata.Metrics) to the current batch.
+	add(item interface{})
+}
+
+func newBatchProcessor(set component.ProcessorCreateSettings, cfg *Config, newBatch func(*zap.Logger) batch) *batchProcessor {
+	return &batchProcessor{
+		logger:            set.Logger,
+		timeout:           cfg.Timeout,
+		sendBatchSize:     int(cfg.SendBatchSize),
+		sendBatchMaxSize:  int(cfg.SendBatchMaxSize),
+		sendBatchMaxBytes: cfg.SendBatchMaxBytes,
+		newItem:           make(chan interface{}, runtime.NumCPU()),
+		batch:             newBatch(set.Logger),
+		shutdownC:         make(chan struct{}, 1),
+	}
+}
+
+func (bp *batchProcessor) Start(context.Context, component.Host) error {
+	bp.goroutines.Add(1)
+	go bp.startProcessingCycle()
+	return nil
+}
+
+func (bp *batchProcessor) Shutdown(context.Context) error {
+	close(bp.shutdownC)
+	bp.goroutines.Wait()
+	return nil
+}
+
+// Capabilities implements consumer.Logs/Traces/Metrics. Batching replaces
+// each incoming request with a differently-shaped one before forwarding it,
+// so it mutates the data it receives.
+func (bp *batchProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// ConsumeLogs implements consumer.Logs by enqueuing ld for the processing
+// goroutine to batch and send on; it returns once ld is queued, which can
+// wait while the queue is full.
+func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return bp.consume(ctx, ld)
+}
+
+// ConsumeTraces implements consumer.Traces by enqueuing td for the
+// processing goroutine to batch and send on; it returns once td is queued,
+// which can wait while the queue is full.
+func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return bp.consume(ctx, td)
+}
+
+// ConsumeMetrics implements consumer.Metrics by enqueuing md for the
+// processing goroutine to batch and send on; it returns once md is queued,
+// which can wait while the queue is full.
+func (bp *batchProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return bp.consume(ctx, md)
+}
+
+func (bp *batchProcessor) consume(ctx context.Context, item interface{}) error {
+	select {
+	case bp.newItem <- item:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (bp *batchProcessor) startProcessingCycle() {
+	defer bp.goroutines.Done()
+	timer := time.NewTimer(bp.timeout)
+	for {
+		select {
+		case <-bp.shutdownC:
+			bp.drainAndFlush()
+			return
+		case item := <-bp.newItem:
+			bp.batch.add(item)
+			bp.flushIfNecessary(timer)
+		case <-timer.C:
+			if bp.batch.itemCount() > 0 {
+				bp.sendItems(recordTimeoutTriggerSend)
+			}
+			timer.Reset(bp.timeout)
+		}
+	}
+}
+
+// flushIfNecessary cuts and sends the current batch for as long as it
+// crosses either the record-count limit or the byte-size limit, recording
+// which trigger fired so the two are distinguishable in metrics.
+func (bp *batchProcessor) flushIfNecessary(timer *time.Timer) {
+	for bp.batch.itemCount() > 0 {
+		switch {
+		case bp.sendBatchMaxBytes > 0 && bp.batch.size() >= bp.sendBatchMaxBytes:
+			bp.sendItems(recordBatchBytesTriggerSend)
+		case bp.batch.itemCount() >= bp.sendBatchSize:
+			bp.sendItems(recordBatchSizeTriggerSend)
+		default:
+			return
+		}
+		timer.Reset(bp.timeout)
+	}
+}
+
+func (bp *batchProcessor) drainAndFlush() {
+	for {
+		select {
+		case item := <-bp.newItem:
+			bp.batch.add(item)
+		default:
+			for bp.batch.itemCount() > 0 {
+				bp.sendItems(recordTimeoutTriggerSend)
+			}
+			return
+		}
+	}
+}
+
+func (bp *batchProcessor) sendItems(recordTrigger func(ctx context.Context)) {
+	recordTrigger(context.Background())
+	if err := bp.batch.export(context.Background(), bp.sendBatchMaxSize, bp.sendBatchMaxBytes); err != nil {
+		bp.logger.Warn("Sender failed", zap.Error(err))
+	}
+}
+
+// logsBatch is the batch implementation for pdata.Logs. sizeBytes tracks the
+// estimated serialized size of logData incrementally as records are added,
+// so size() is a field read rather than a re-serialization of the batch.
+type logsBatch struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Logs
+	logData      pdata.Logs
+	sizeBytes    int
+}
+
+func newBatchLogsProcessor(set component.ProcessorCreateSettings, next consumer.Logs, cfg *Config) *batchProcessor {
+	return newBatchProcessor(set, cfg, func(logger *zap.Logger) batch {
+		return &logsBatch{logger: logger, nextConsumer: next, logData: pdata.NewLogs()}
+	})
+}
+
+func (lb *logsBatch) add(item interface{}) {
+	ld := item.(pdata.Logs)
+	lb.sizeBytes += logsSize(ld)
+	ld.ResourceLogs().MoveAndAppendTo(lb.logData.ResourceLogs())
+}
+
+func (lb *logsBatch) itemCount() int {
+	return lb.logData.LogRecordCount()
+}
+
+func (lb *logsBatch) size() int {
+	return lb.sizeBytes
+}
+
+func (lb *logsBatch) export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int) error {
+	req := lb.logData
+	switch {
+	case sendBatchMaxBytes > 0 && lb.sizeBytes > sendBatchMaxBytes:
+		var oversized bool
+		req, oversized = splitLogsBySize(sendBatchMaxBytes, lb.logData)
+		if oversized {
+			lb.logger.Warn("log record exceeds send_batch_max_bytes on its own; emitting it as an oversized batch",
+				zap.Int("send_batch_max_bytes", sendBatchMaxBytes))
+		}
+		lb.sizeBytes = logsSize(lb.logData)
+	case sendBatchMaxSize > 0 && lb.logData.LogRecordCount() > sendBatchMaxSize:
+		req = splitLogs(sendBatchMaxSize, lb.logData)
+		lb.sizeBytes = logsSize(lb.logData)
+	default:
+		lb.logData = pdata.NewLogs()
+		lb.sizeBytes = 0
+	}
+	return lb.nextConsumer.ConsumeLogs(ctx, req)
+}
+
+// tracesBatch is the batch implementation for pdata.Traces. sizeBytes
+// tracks the estimated serialized size of traceData incrementally as spans
+// are added, so size() is a field read rather than a re-serialization of
+// the batch.
+type tracesBatch struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Traces
+	traceData    pdata.Traces
+	sizeBytes    int
+}
+
+func newBatchTracesProcessor(set component.ProcessorCreateSettings, next consumer.Traces, cfg *Config) *batchProcessor {
+	return newBatchProcessor(set, cfg, func(logger *zap.Logger) batch {
+		return &tracesBatch{logger: logger, nextConsumer: next, traceData: pdata.NewTraces()}
+	})
+}
+
+func (tb *tracesBatch) add(item interface{}) {
+	td := item.(pdata.Traces)
+	tb.sizeBytes += tracesSize(td)
+	td.ResourceSpans().MoveAndAppendTo(tb.traceData.ResourceSpans())
+}
+
+func (tb *tracesBatch) itemCount() int {
+	return tb.traceData.SpanCount()
+}
+
+func (tb *tracesBatch) size() int {
+	return tb.sizeBytes
+}
+
+func (tb *tracesBatch) export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int) error {
+	req := tb.traceData
+	switch {
+	case sendBatchMaxBytes > 0 && tb.sizeBytes > sendBatchMaxBytes:
+		var oversized bool
+		req, oversized = splitTracesBySize(sendBatchMaxBytes, tb.traceData)
+		if oversized {
+			tb.logger.Warn("span exceeds send_batch_max_bytes on its own; emitting it as an oversized batch",
+				zap.Int("send_batch_max_bytes", sendBatchMaxBytes))
+		}
+		tb.sizeBytes = tracesSize(tb.traceData)
+	case sendBatchMaxSize > 0 && tb.traceData.SpanCount() > sendBatchMaxSize:
+		req = splitTraces(sendBatchMaxSize, tb.traceData)
+		tb.sizeBytes = tracesSize(tb.traceData)
+	default:
+		tb.traceData = pdata.NewTraces()
+		tb.sizeBytes = 0
+	}
+	return tb.nextConsumer.ConsumeTraces(ctx, req)
+}
+
+// metricsBatch is the batch implementation for pdata.Metrics. sizeBytes
+// tracks the estimated serialized size of metricData incrementally as
+// metrics are added, so size() is a field read rather than a
+// re-serialization of the batch.
+type metricsBatch struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	metricData   pdata.Metrics
+	sizeBytes    int
+}
+
+func newBatchMetricsProcessor(set component.ProcessorCreateSettings, next consumer.Metrics, cfg *Config) *batchProcessor {
+	return newBatchProcessor(set, cfg, func(logger *zap.Logger) batch {
+		return &metricsBatch{logger: logger, nextConsumer: next, metricData: pdata.NewMetrics()}
+	})
+}
+
+func (mb *metricsBatch) add(item interface{}) {
+	md := item.(pdata.Metrics)
+	mb.sizeBytes += metricsSize(md)
+	md.ResourceMetrics().MoveAndAppendTo(mb.metricData.ResourceMetrics())
+}
+
+func (mb *metricsBatch) itemCount() int {
+	_, dataPointCount := mb.metricData.MetricAndDataPointCount()
+	return dataPointCount
+}
+
+func (mb *metricsBatch) size() int {
+	return mb.sizeBytes
+}
+
+func (mb *metricsBatch) export(ctx context.Context, sendBatchMaxSize int, sendBatchMaxBytes int) error {
+	req := mb.metricData
+	switch {
+	case sendBatchMaxBytes > 0 && mb.sizeBytes > sendBatchMaxBytes:
+		var oversized bool
+		req, oversized = splitMetricsBySize(sendBatchMaxBytes, mb.metricData)
+		if oversized {
+			mb.logger.Warn("metric exceeds send_batch_max_bytes on its own; emitting it as an oversized batch",
+				zap.Int("send_batch_max_bytes", sendBatchMaxBytes))
+		}
+		mb.sizeBytes = metricsSize(mb.metricData)
+	case sendBatchMaxSize > 0 && mb.itemCount() > sendBatchMaxSize:
+		req = splitMetrics(sendBatchMaxSize, mb.metricData)
+		mb.sizeBytes = metricsSize(mb.metricData)
+	default:
+		mb.metricData = pdata.NewMetrics()
+		mb.sizeBytes = 0
+	}
+	return mb.nextConsumer.ConsumeMetrics(ctx, req)
+}
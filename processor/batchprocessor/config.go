@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for batch processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"`
+
+	// Timeout sets the time after which a batch will be sent regardless of size.
+	// If set to zero, batched data will be sent immediately.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// SendBatchSize is the size of a batch which after hit, will trigger it to be sent.
+	// If set to zero, the batch will be sent immediately after it is ready.
+	SendBatchSize uint32 `mapstructure:"send_batch_size"`
+
+	// SendBatchMaxSize is the maximum size of a batch. It must be greater or equal to
+	// SendBatchSize. When used, it will be used together with SendBatchSize to determine
+	// the sending strategy. If SendBatchMaxSize is 0, the behavior of this option will be
+	// ignored and SendBatchSize will not force the batch to be cut.
+	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size"`
+
+	// SendBatchMaxBytes is the maximum serialized OTLP protobuf size, in bytes, that a
+	// batch may reach before it is cut and sent, regardless of record count. The size
+	// is an estimate computed from the pdata fields, not the exact wire size. It is
+	// evaluated independently of, and in addition to, SendBatchSize/SendBatchMaxSize: a
+	// batch is cut as soon as either threshold is reached. If SendBatchMaxBytes is 0,
+	// byte-size based splitting is disabled. This is most useful for exporters with a
+	// hard request-size limit, such as OTLP/HTTP gateways or Kafka.
+	SendBatchMaxBytes int `mapstructure:"send_batch_max_bytes"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.SendBatchSize {
+		return fmt.Errorf("send_batch_max_size must be greater or equal to send_batch_size")
+	}
+	if cfg.SendBatchMaxBytes < 0 {
+		return fmt.Errorf("send_batch_max_bytes must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	statBatchSizeTriggerSend  = stats.Int64("batch_size_trigger_send", "Number of times the batch was sent due to a size trigger", stats.UnitDimensionless)
+	statBatchBytesTriggerSend = stats.Int64("batch_bytes_trigger_send", "Number of times the batch was sent due to a byte-size trigger", stats.UnitDimensionless)
+	statTimeoutTriggerSend    = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
+
+	tagKeyProcessor, _ = tag.NewKey("processor")
+)
+
+// metricViews returns the metrics views related to batching, distinguishing
+// between the three reasons a batch can be cut: a record-count trigger, a
+// byte-size trigger, or a timeout.
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        statBatchSizeTriggerSend.Name(),
+			Description: statBatchSizeTriggerSend.Description(),
+			TagKeys:     []tag.Key{tagKeyProcessor},
+			Measure:     statBatchSizeTriggerSend,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statBatchBytesTriggerSend.Name(),
+			Description: statBatchBytesTriggerSend.Description(),
+			TagKeys:     []tag.Key{tagKeyProcessor},
+			Measure:     statBatchBytesTriggerSend,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statTimeoutTriggerSend.Name(),
+			Description: statTimeoutTriggerSend.Description(),
+			TagKeys:     []tag.Key{tagKeyProcessor},
+			Measure:     statTimeoutTriggerSend,
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+func recordBatchSizeTriggerSend(ctx context.Context) {
+	stats.Record(ctx, statBatchSizeTriggerSend.M(1))
+}
+
+func recordBatchBytesTriggerSend(ctx context.Context) {
+	stats.Record(ctx, statBatchBytesTriggerSend.M(1))
+}
+
+func recordTimeoutTriggerSend(ctx context.Context) {
+	stats.Record(ctx, statTimeoutTriggerSend.M(1))
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// The functions in this file estimate the serialized OTLP protobuf size of
+// a pdata payload. The original request asked for this to be measured using
+// pdata's Sizer; model/otlp's protobuf marshalers do not expose one at this
+// API vintage, so rather than assume one exists (and panic at package-init
+// time if it doesn't), these compute an estimate directly from the
+// already-available pdata accessors instead: a small constant per field for
+// its tag and length-prefix, plus the length of each string/bytes/numeric
+// value.
+//
+// This is a deliberate deviation from the spec, not just a fallback detail:
+// the result is NOT byte-exact protobuf wire size, so send_batch_max_bytes
+// is an approximation of the true request size, not an exact bound. It is
+// monotonic in the data added and, crucially, cheap to compute for a single
+// new item — which is what lets splitXBySize track a running total instead
+// of re-measuring the whole accumulated batch after every append — but any
+// sizing bug report should check here first for drift against the real
+// OTLP wire size before assuming the bug is elsewhere.
+const perFieldOverhead = 2
+
+func logsSize(ld pdata.Logs) int {
+	size := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		size += resourceLogsSize(rls.At(i))
+	}
+	return size
+}
+
+func resourceLogsSize(rl pdata.ResourceLogs) int {
+	size := resourceOverheadSize(rl.Resource())
+	sls := rl.ScopeLogs()
+	for i := 0; i < sls.Len(); i++ {
+		size += scopeLogsOverheadSize(sls.At(i))
+		lrs := sls.At(i).LogRecords()
+		for j := 0; j < lrs.Len(); j++ {
+			size += logRecordSize(lrs.At(j))
+		}
+	}
+	return size
+}
+
+func scopeLogsOverheadSize(sl pdata.ScopeLogs) int {
+	return scopeOverheadSize(sl.Scope())
+}
+
+func logRecordSize(lr pdata.LogRecord) int {
+	return perFieldOverhead +
+		16 + // time_unix_nano + observed_time_unix_nano (fixed64 each)
+		len(lr.SeverityText()) +
+		valueSize(lr.Body()) +
+		attributesSize(lr.Attributes()) +
+		24 // trace_id + span_id
+}
+
+func tracesSize(td pdata.Traces) int {
+	size := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		size += resourceSpansSize(rss.At(i))
+	}
+	return size
+}
+
+func resourceSpansSize(rs pdata.ResourceSpans) int {
+	size := resourceOverheadSize(rs.Resource())
+	sss := rs.ScopeSpans()
+	for i := 0; i < sss.Len(); i++ {
+		size += scopeOverheadSize(sss.At(i).Scope())
+		spans := sss.At(i).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			size += spanSize(spans.At(j))
+		}
+	}
+	return size
+}
+
+func spanSize(sp pdata.Span) int {
+	size := perFieldOverhead +
+		32 + // trace_id + span_id
+		len(sp.TraceState().AsRaw()) +
+		len(sp.Name()) +
+		16 + // start_time_unix_nano + end_time_unix_nano
+		attributesSize(sp.Attributes()) +
+		len(sp.Status().Message())
+
+	events := sp.Events()
+	for i := 0; i < events.Len(); i++ {
+		e := events.At(i)
+		size += perFieldOverhead + 8 + len(e.Name()) + attributesSize(e.Attributes())
+	}
+
+	links := sp.Links()
+	for i := 0; i < links.Len(); i++ {
+		l := links.At(i)
+		size += perFieldOverhead + 32 + len(l.TraceState().AsRaw()) + attributesSize(l.Attributes())
+	}
+
+	return size
+}
+
+func metricsSize(md pdata.Metrics) int {
+	size := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		size += resourceMetricsSize(rms.At(i))
+	}
+	return size
+}
+
+func resourceMetricsSize(rm pdata.ResourceMetrics) int {
+	size := resourceOverheadSize(rm.Resource())
+	sms := rm.ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		size += scopeOverheadSize(sms.At(i).Scope())
+		metrics := sms.At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			size += metricSize(metrics.At(j))
+		}
+	}
+	return size
+}
+
+func metricSize(m pdata.Metric) int {
+	size := perFieldOverhead + len(m.Name()) + len(m.Description()) + len(m.Unit())
+
+	switch m.DataType() {
+	case pdata.MetricDataTypeGauge:
+		size += numberDataPointsSize(m.Gauge().DataPoints())
+	case pdata.MetricDataTypeSum:
+		size += numberDataPointsSize(m.Sum().DataPoints())
+	case pdata.MetricDataTypeHistogram:
+		ps := m.Histogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			size += dataPointOverheadSize(p.Attributes()) + 16 + len(p.BucketCounts())*8 + len(p.ExplicitBounds())*8
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		ps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			size += dataPointOverheadSize(p.Attributes()) + 16 +
+				len(p.Positive().BucketCounts())*8 + len(p.Negative().BucketCounts())*8
+		}
+	case pdata.MetricDataTypeSummary:
+		ps := m.Summary().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			size += dataPointOverheadSize(p.Attributes()) + 16 + p.QuantileValues().Len()*16
+		}
+	}
+
+	return size
+}
+
+func numberDataPointsSize(ps pdata.NumberDataPointSlice) int {
+	size := 0
+	for i := 0; i < ps.Len(); i++ {
+		p := ps.At(i)
+		size += dataPointOverheadSize(p.Attributes()) + 16
+	}
+	return size
+}
+
+func dataPointOverheadSize(attrs pdata.Map) int {
+	return perFieldOverhead + attributesSize(attrs)
+}
+
+func resourceOverheadSize(r pdata.Resource) int {
+	return perFieldOverhead + attributesSize(r.Attributes())
+}
+
+func scopeOverheadSize(il pdata.InstrumentationScope) int {
+	return perFieldOverhead + len(il.Name()) + len(il.Version())
+}
+
+func attributesSize(m pdata.Map) int {
+	size := 0
+	m.Range(func(k string, v pdata.Value) bool {
+		size += perFieldOverhead + len(k) + valueSize(v)
+		return true
+	})
+	return size
+}
+
+func valueSize(v pdata.Value) int {
+	switch v.Type() {
+	case pdata.ValueTypeString:
+		return perFieldOverhead + len(v.StringVal())
+	case pdata.ValueTypeBool:
+		return perFieldOverhead + 1
+	case pdata.ValueTypeInt, pdata.ValueTypeDouble:
+		return perFieldOverhead + 8
+	case pdata.ValueTypeSlice:
+		s := v.SliceVal()
+		size := perFieldOverhead
+		for i := 0; i < s.Len(); i++ {
+			size += valueSize(s.At(i))
+		}
+		return size
+	case pdata.ValueTypeMap:
+		return perFieldOverhead + attributesSize(v.MapVal())
+	default:
+		return perFieldOverhead
+	}
+}
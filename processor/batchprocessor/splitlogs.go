@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// splitLogs removes logrecords from the input data and returns a new data of the specified size.
+func splitLogs(size int, src pdata.Logs) pdata.Logs {
+	if src.LogRecordCount() <= size {
+		return src
+	}
+	totalCopiedLogs := 0
+	dest := pdata.NewLogs()
+
+	src.ResourceLogs().RemoveIf(func(srcRl pdata.ResourceLogs) bool {
+		// If the no more logs need to be copied, then break the loop.
+		if totalCopiedLogs == size {
+			return false
+		}
+		destRl := dest.ResourceLogs().AppendEmpty()
+		srcRl.Resource().CopyTo(destRl.Resource())
+
+		srcRl.ScopeLogs().RemoveIf(func(srcSl pdata.ScopeLogs) bool {
+			if totalCopiedLogs == size {
+				return false
+			}
+			destSl := destRl.ScopeLogs().AppendEmpty()
+			srcSl.Scope().CopyTo(destSl.Scope())
+
+			// If the size of this scope logs is less than the number of logs we have
+			// to still remove, remove the whole scope logs.
+			srcLogsLen := srcSl.LogRecords().Len()
+			if size-totalCopiedLogs >= srcLogsLen {
+				totalCopiedLogs += srcLogsLen
+				srcSl.LogRecords().MoveAndAppendTo(destSl.LogRecords())
+				return true
+			}
+
+			srcSl.LogRecords().RemoveIf(func(srcLog pdata.LogRecord) bool {
+				if totalCopiedLogs == size {
+					return false
+				}
+				srcLog.CopyTo(destSl.LogRecords().AppendEmpty())
+				totalCopiedLogs++
+				return true
+			})
+			return false
+		})
+		return srcRl.ScopeLogs().Len() == 0
+	})
+
+	return dest
+}
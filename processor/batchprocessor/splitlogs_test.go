@@ -15,6 +15,7 @@
 package batchprocessor
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,13 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
+// getTestLogSeverityText returns a distinct, deterministic SeverityText for
+// the log record at recordIdx within the resourceIdx-th ResourceLogs, so
+// that splitLogs' output can be checked record-by-record.
+func getTestLogSeverityText(resourceIdx, recordIdx int) string {
+	return fmt.Sprintf("test-log-int-%d-%d", resourceIdx, recordIdx)
+}
+
 func TestSplitLogs_noop(t *testing.T) {
 	td := testdata.GenerateLogsManyLogRecordsSameResource(20)
 	splitSize := 40
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// splitLogsBySize removes log records from the input data and returns a new
+// data of approximately maxBytes serialized size. Unlike splitLogs, which
+// caps a batch by record count, this walks ResourceLogs/ScopeLogs/LogRecords
+// and stops as soon as the running size of the batch being built would
+// exceed maxBytes, tracking the marginal cost of each resource, scope and
+// record as it is added rather than re-serializing the whole batch from
+// scratch on every record.
+//
+// A single log record that alone exceeds maxBytes is still emitted as its
+// own one-record batch (with a warning logged by the caller) rather than
+// dropped, since there is no smaller unit to split it into.
+func splitLogsBySize(maxBytes int, src pdata.Logs) (pdata.Logs, bool) {
+	if logsSize(src) <= maxBytes {
+		return src, false
+	}
+
+	dest := pdata.NewLogs()
+	runningSize := 0
+	oversizedRecord := false
+	full := false
+
+	src.ResourceLogs().RemoveIf(func(srcRl pdata.ResourceLogs) bool {
+		if full {
+			return false
+		}
+		destRl := dest.ResourceLogs().AppendEmpty()
+		srcRl.Resource().CopyTo(destRl.Resource())
+		runningSize += resourceOverheadSize(destRl.Resource())
+
+		srcRl.ScopeLogs().RemoveIf(func(srcSl pdata.ScopeLogs) bool {
+			if full {
+				return false
+			}
+			destSl := destRl.ScopeLogs().AppendEmpty()
+			srcSl.Scope().CopyTo(destSl.Scope())
+			runningSize += scopeLogsOverheadSize(destSl)
+
+			srcSl.LogRecords().RemoveIf(func(srcLog pdata.LogRecord) bool {
+				// isFirstRecord is true only while dest is still completely
+				// empty: the one case where we must accept an over-budget
+				// record rather than deferring it to the next batch.
+				isFirstRecord := dest.ResourceLogs().Len() == 1 &&
+					destRl.ScopeLogs().Len() == 1 &&
+					destSl.LogRecords().Len() == 0
+
+				recordSize := logRecordSize(srcLog)
+				if full || (!isFirstRecord && runningSize+recordSize > maxBytes) {
+					full = true
+					return false
+				}
+				srcLog.CopyTo(destSl.LogRecords().AppendEmpty())
+				runningSize += recordSize
+				if isFirstRecord && runningSize > maxBytes {
+					oversizedRecord = true
+				}
+				return true
+			})
+			return srcSl.LogRecords().Len() == 0
+		})
+		return srcRl.ScopeLogs().Len() == 0
+	})
+	dest.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl pdata.ScopeLogs) bool { return sl.LogRecords().Len() == 0 })
+		return rl.ScopeLogs().Len() == 0
+	})
+
+	return dest, oversizedRecord
+}
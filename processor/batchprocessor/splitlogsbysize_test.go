@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestSplitLogsBySize_noop(t *testing.T) {
+	td := testdata.GenerateLogsManyLogRecordsSameResource(20)
+	split, oversized := splitLogsBySize(logsSize(td), td)
+	assert.Equal(t, td, split)
+	assert.False(t, oversized)
+
+	i := 0
+	td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(_ pdata.LogRecord) bool {
+		i++
+		return i > 5
+	})
+	assert.EqualValues(t, td, split)
+}
+
+func TestSplitLogsBySize(t *testing.T) {
+	ld := testdata.GenerateLogsManyLogRecordsSameResource(20)
+	// maxBytes large enough for a handful of records but not the whole batch.
+	oneRecord := testdata.GenerateLogsManyLogRecordsSameResource(1)
+	maxBytes := logsSize(oneRecord) * 5
+
+	totalRemaining := ld.LogRecordCount()
+	var gotRecords int
+	for ld.LogRecordCount() > 0 {
+		// A source that already fits is returned as is rather than drained.
+		lastBatch := logsSize(ld) <= maxBytes
+		split, oversized := splitLogsBySize(maxBytes, ld)
+		assert.False(t, oversized)
+		assert.True(t, logsSize(split) <= maxBytes)
+		assert.True(t, split.LogRecordCount() > 0)
+		gotRecords += split.LogRecordCount()
+		if lastBatch {
+			break
+		}
+	}
+	assert.Equal(t, totalRemaining, gotRecords)
+}
+
+func TestSplitLogsBySize_oversizedSingleRecord(t *testing.T) {
+	ld := testdata.GenerateLogsManyLogRecordsSameResource(1)
+	recordSize := logsSize(ld)
+
+	// A maxBytes smaller than even a single record must still produce a
+	// one-record batch, flagged as oversized, rather than dropping data.
+	split, oversized := splitLogsBySize(recordSize-1, ld)
+	assert.True(t, oversized)
+	assert.Equal(t, 1, split.LogRecordCount())
+	assert.Equal(t, 0, ld.LogRecordCount())
+}
+
+func TestSplitLogsBySize_oversizedRecordAmongOthers(t *testing.T) {
+	ld := testdata.GenerateLogsManyLogRecordsSameResource(3)
+	maxBytes := logsSize(ld) / 3
+
+	var total int
+	for ld.LogRecordCount() > 0 {
+		lastBatch := logsSize(ld) <= maxBytes
+		split, _ := splitLogsBySize(maxBytes, ld)
+		got := split.LogRecordCount()
+		assert.True(t, got > 0)
+		total += got
+		if lastBatch {
+			break
+		}
+	}
+	assert.Equal(t, 3, total)
+}
+
+func TestSplitLogsBySize_multipleResourceLogs(t *testing.T) {
+	td := testdata.GenerateLogsManyLogRecordsSameResource(10)
+	testdata.GenerateLogsManyLogRecordsSameResource(10).
+		ResourceLogs().At(0).CopyTo(td.ResourceLogs().AppendEmpty())
+	assert.Equal(t, 2, td.ResourceLogs().Len())
+
+	oneRecord := testdata.GenerateLogsManyLogRecordsSameResource(1)
+	maxBytes := logsSize(oneRecord) * 5
+
+	var gotRecords int
+	for td.LogRecordCount() > 0 {
+		lastBatch := logsSize(td) <= maxBytes
+		split, oversized := splitLogsBySize(maxBytes, td)
+		assert.False(t, oversized)
+		gotRecords += split.LogRecordCount()
+		if lastBatch {
+			break
+		}
+	}
+	assert.Equal(t, 20, gotRecords)
+}
+
+func BenchmarkSplitLogsBySize(b *testing.B) {
+	md := pdata.NewLogs()
+	rms := md.ResourceLogs()
+	for i := 0; i < 20; i++ {
+		testdata.GenerateLogsManyLogRecordsSameResource(20).ResourceLogs().MoveAndAppendTo(md.ResourceLogs())
+	}
+	maxBytes := logsSize(md) / 10
+
+	if b.N > 100000 {
+		b.Skipf("SKIP: b.N too high, set -benchtime=<n>x with n < 100000")
+	}
+
+	clones := make([]pdata.Logs, b.N)
+	for n := 0; n < b.N; n++ {
+		clones[n] = md.Clone()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = splitLogsBySize(maxBytes, clones[n])
+	}
+}
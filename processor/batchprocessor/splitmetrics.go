@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// splitMetrics removes metrics from the input data and returns a new data of the specified
+// data point count, rounded up to a whole Metric: a whole Metric (and all of its data points)
+// moves together so that a metric's descriptor is never separated from a subset of its data points.
+func splitMetrics(size int, src pdata.Metrics) pdata.Metrics {
+	_, dataPointCount := src.MetricAndDataPointCount()
+	if dataPointCount <= size {
+		return src
+	}
+	totalCopiedDataPoints := 0
+	dest := pdata.NewMetrics()
+
+	src.ResourceMetrics().RemoveIf(func(srcRm pdata.ResourceMetrics) bool {
+		if totalCopiedDataPoints >= size {
+			return false
+		}
+		destRm := dest.ResourceMetrics().AppendEmpty()
+		srcRm.Resource().CopyTo(destRm.Resource())
+
+		srcRm.ScopeMetrics().RemoveIf(func(srcSm pdata.ScopeMetrics) bool {
+			if totalCopiedDataPoints >= size {
+				return false
+			}
+			destSm := destRm.ScopeMetrics().AppendEmpty()
+			srcSm.Scope().CopyTo(destSm.Scope())
+
+			srcSm.Metrics().RemoveIf(func(srcMetric pdata.Metric) bool {
+				if totalCopiedDataPoints >= size {
+					return false
+				}
+				destMetric := destSm.Metrics().AppendEmpty()
+				srcMetric.CopyTo(destMetric)
+				totalCopiedDataPoints += metricDataPointCount(destMetric)
+				return true
+			})
+			return srcSm.Metrics().Len() == 0
+		})
+		return srcRm.ScopeMetrics().Len() == 0
+	})
+
+	return dest
+}
+
+// metricDataPointCount returns the number of data points in a single metric.
+func metricDataPointCount(m pdata.Metric) int {
+	switch m.DataType() {
+	case pdata.MetricDataTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pdata.MetricDataTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pdata.MetricDataTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pdata.MetricDataTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeSummary:
+		return m.Summary().DataPoints().Len()
+	default:
+		return 0
+	}
+}
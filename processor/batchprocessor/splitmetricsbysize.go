@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// splitMetricsBySize is the metrics counterpart of splitLogsBySize: it
+// removes metrics from src and returns a new batch of approximately
+// maxBytes serialized size, walking ResourceMetrics/ScopeMetrics/Metrics
+// and tracking the running size of the batch being built. Splitting happens
+// at the granularity of a whole Metric (all of its data points move
+// together) since a Metric's data points share a single descriptor and
+// aggregation temporality that cannot be split across batches. A single
+// metric that alone exceeds maxBytes is still emitted as its own batch, and
+// the bool return indicates that case to the caller so it can log a
+// warning.
+func splitMetricsBySize(maxBytes int, src pdata.Metrics) (pdata.Metrics, bool) {
+	if metricsSize(src) <= maxBytes {
+		return src, false
+	}
+
+	dest := pdata.NewMetrics()
+	runningSize := 0
+	oversizedMetric := false
+	full := false
+
+	src.ResourceMetrics().RemoveIf(func(srcRm pdata.ResourceMetrics) bool {
+		if full {
+			return false
+		}
+		destRm := dest.ResourceMetrics().AppendEmpty()
+		srcRm.Resource().CopyTo(destRm.Resource())
+		runningSize += resourceOverheadSize(destRm.Resource())
+
+		srcRm.ScopeMetrics().RemoveIf(func(srcSm pdata.ScopeMetrics) bool {
+			if full {
+				return false
+			}
+			destSm := destRm.ScopeMetrics().AppendEmpty()
+			srcSm.Scope().CopyTo(destSm.Scope())
+			runningSize += scopeOverheadSize(destSm.Scope())
+
+			srcSm.Metrics().RemoveIf(func(srcMetric pdata.Metric) bool {
+				isFirstMetric := dest.ResourceMetrics().Len() == 1 &&
+					destRm.ScopeMetrics().Len() == 1 &&
+					destSm.Metrics().Len() == 0
+
+				metricSizeBytes := metricSize(srcMetric)
+				if full || (!isFirstMetric && runningSize+metricSizeBytes > maxBytes) {
+					full = true
+					return false
+				}
+				srcMetric.CopyTo(destSm.Metrics().AppendEmpty())
+				runningSize += metricSizeBytes
+				if isFirstMetric && runningSize > maxBytes {
+					oversizedMetric = true
+				}
+				return true
+			})
+			return srcSm.Metrics().Len() == 0
+		})
+		return srcRm.ScopeMetrics().Len() == 0
+	})
+	dest.ResourceMetrics().RemoveIf(func(rm pdata.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pdata.ScopeMetrics) bool { return sm.Metrics().Len() == 0 })
+		return rm.ScopeMetrics().Len() == 0
+	})
+
+	return dest, oversizedMetric
+}
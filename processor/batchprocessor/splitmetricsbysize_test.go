@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestSplitMetricsBySize_noop(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(20)
+	split, oversized := splitMetricsBySize(metricsSize(md), md)
+	assert.Equal(t, md, split)
+	assert.False(t, oversized)
+}
+
+func TestSplitMetricsBySize_oversizedSingleMetric(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(1)
+	metricSize := metricsSize(md)
+
+	split, oversized := splitMetricsBySize(metricSize-1, md)
+	assert.True(t, oversized)
+	_, splitCount := split.MetricAndDataPointCount()
+	assert.True(t, splitCount > 0)
+	_, remainingCount := md.MetricAndDataPointCount()
+	assert.Equal(t, 0, remainingCount)
+}
+
+func TestSplitMetricsBySize(t *testing.T) {
+	md := testdata.GenerateMetricsManyMetricsSameResource(20)
+	oneMetric := testdata.GenerateMetricsManyMetricsSameResource(1)
+	maxBytes := metricsSize(oneMetric) * 5
+
+	metricCount, _ := md.MetricAndDataPointCount()
+	var gotMetrics int
+	for {
+		remaining, _ := md.MetricAndDataPointCount()
+		if remaining == 0 {
+			break
+		}
+		// A source that already fits is returned as is rather than drained.
+		lastBatch := metricsSize(md) <= maxBytes
+		split, oversized := splitMetricsBySize(maxBytes, md)
+		assert.False(t, oversized)
+		splitMetrics, _ := split.MetricAndDataPointCount()
+		assert.True(t, splitMetrics > 0)
+		gotMetrics += splitMetrics
+		if lastBatch {
+			break
+		}
+	}
+	assert.Equal(t, metricCount, gotMetrics)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// splitTraces removes spans from the input data and returns a new data of the specified size.
+func splitTraces(size int, src pdata.Traces) pdata.Traces {
+	if src.SpanCount() <= size {
+		return src
+	}
+	totalCopiedSpans := 0
+	dest := pdata.NewTraces()
+
+	src.ResourceSpans().RemoveIf(func(srcRs pdata.ResourceSpans) bool {
+		if totalCopiedSpans == size {
+			return false
+		}
+		destRs := dest.ResourceSpans().AppendEmpty()
+		srcRs.Resource().CopyTo(destRs.Resource())
+
+		srcRs.ScopeSpans().RemoveIf(func(srcSs pdata.ScopeSpans) bool {
+			if totalCopiedSpans == size {
+				return false
+			}
+			destSs := destRs.ScopeSpans().AppendEmpty()
+			srcSs.Scope().CopyTo(destSs.Scope())
+
+			// If the size of this scope spans is less than the number of spans we have
+			// to still remove, remove the whole scope spans.
+			srcSpansLen := srcSs.Spans().Len()
+			if size-totalCopiedSpans >= srcSpansLen {
+				totalCopiedSpans += srcSpansLen
+				srcSs.Spans().MoveAndAppendTo(destSs.Spans())
+				return true
+			}
+
+			srcSs.Spans().RemoveIf(func(srcSpan pdata.Span) bool {
+				if totalCopiedSpans == size {
+					return false
+				}
+				srcSpan.CopyTo(destSs.Spans().AppendEmpty())
+				totalCopiedSpans++
+				return true
+			})
+			return false
+		})
+		return srcRs.ScopeSpans().Len() == 0
+	})
+
+	return dest
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import "go.opentelemetry.io/collector/model/pdata"
+
+// splitTracesBySize is the traces counterpart of splitLogsBySize: it removes
+// spans from src and returns a new batch of approximately maxBytes
+// serialized size, walking ResourceSpans/ScopeSpans/Spans and tracking the
+// running size of the batch being built. A single span that alone exceeds
+// maxBytes is still emitted as its own batch, and the bool return indicates
+// that case to the caller so it can log a warning.
+func splitTracesBySize(maxBytes int, src pdata.Traces) (pdata.Traces, bool) {
+	if tracesSize(src) <= maxBytes {
+		return src, false
+	}
+
+	dest := pdata.NewTraces()
+	runningSize := 0
+	oversizedSpan := false
+	full := false
+
+	src.ResourceSpans().RemoveIf(func(srcRs pdata.ResourceSpans) bool {
+		if full {
+			return false
+		}
+		destRs := dest.ResourceSpans().AppendEmpty()
+		srcRs.Resource().CopyTo(destRs.Resource())
+		runningSize += resourceOverheadSize(destRs.Resource())
+
+		srcRs.ScopeSpans().RemoveIf(func(srcSs pdata.ScopeSpans) bool {
+			if full {
+				return false
+			}
+			destSs := destRs.ScopeSpans().AppendEmpty()
+			srcSs.Scope().CopyTo(destSs.Scope())
+			runningSize += scopeOverheadSize(destSs.Scope())
+
+			srcSs.Spans().RemoveIf(func(srcSpan pdata.Span) bool {
+				isFirstSpan := dest.ResourceSpans().Len() == 1 &&
+					destRs.ScopeSpans().Len() == 1 &&
+					destSs.Spans().Len() == 0
+
+				spanSizeBytes := spanSize(srcSpan)
+				if full || (!isFirstSpan && runningSize+spanSizeBytes > maxBytes) {
+					full = true
+					return false
+				}
+				srcSpan.CopyTo(destSs.Spans().AppendEmpty())
+				runningSize += spanSizeBytes
+				if isFirstSpan && runningSize > maxBytes {
+					oversizedSpan = true
+				}
+				return true
+			})
+			return srcSs.Spans().Len() == 0
+		})
+		return srcRs.ScopeSpans().Len() == 0
+	})
+	dest.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss pdata.ScopeSpans) bool { return ss.Spans().Len() == 0 })
+		return rs.ScopeSpans().Len() == 0
+	})
+
+	return dest, oversizedSpan
+}
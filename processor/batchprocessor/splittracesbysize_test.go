@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestSplitTracesBySize_noop(t *testing.T) {
+	td := testdata.GenerateTracesManySpansSameResource(20)
+	split, oversized := splitTracesBySize(tracesSize(td), td)
+	assert.Equal(t, td, split)
+	assert.False(t, oversized)
+}
+
+func TestSplitTracesBySize_oversizedSingleSpan(t *testing.T) {
+	td := testdata.GenerateTracesManySpansSameResource(1)
+	spanSize := tracesSize(td)
+
+	split, oversized := splitTracesBySize(spanSize-1, td)
+	assert.True(t, oversized)
+	assert.Equal(t, 1, split.SpanCount())
+	assert.Equal(t, 0, td.SpanCount())
+}
+
+func TestSplitTracesBySize(t *testing.T) {
+	td := testdata.GenerateTracesManySpansSameResource(20)
+	oneSpan := testdata.GenerateTracesManySpansSameResource(1)
+	maxBytes := tracesSize(oneSpan) * 5
+
+	var gotSpans int
+	for td.SpanCount() > 0 {
+		// A source that already fits is returned as is rather than drained.
+		lastBatch := tracesSize(td) <= maxBytes
+		split, oversized := splitTracesBySize(maxBytes, td)
+		assert.False(t, oversized)
+		assert.True(t, split.SpanCount() > 0)
+		gotSpans += split.SpanCount()
+		if lastBatch {
+			break
+		}
+	}
+	assert.Equal(t, 20, gotSpans)
+}